@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"auth/tools/rest"
+	"auth/user"
+
+	"github.com/gin-gonic/gin"
+)
+
+/**
+ * @api {get} /v1/users/me Current User
+ * @apiName currentUser
+ * @apiGroup Users
+ *
+ * @apiDescription Returns the profile of the user owning the bearer token.
+ *
+ * @apiUse OtherErrors
+ */
+// Me is the controller for GET /v1/users/me
+func Me(c *gin.Context) {
+	profile, err := user.CurrentUser(bearerToken(c))
+	if err != nil {
+		rest.HandleError(c, err)
+		return
+	}
+
+	c.JSON(200, profile)
+}
@@ -0,0 +1,20 @@
+package controller
+
+import "github.com/gin-gonic/gin"
+
+// RegisterRoutes mounts both API surfaces on r: the legacy /auth/* routes,
+// kept so existing clients don't break, and the resource-oriented /v1/*
+// routes new clients should use. Both delegate to the same controllers, so
+// validation and error handling stay identical between them.
+func RegisterRoutes(r *gin.Engine) {
+	auth := r.Group("/auth")
+	auth.POST("/signup", SignUpHandlers...)
+	auth.POST("/login", Login)
+	auth.POST("/logout", Logout)
+
+	v1 := r.Group("/v1")
+	v1.POST("/users", CreateUserHandlers...)
+	v1.GET("/users/me", Me)
+	v1.POST("/sessions", CreateSession)
+	v1.DELETE("/sessions/current", EndCurrentSession)
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"auth/tools/rest"
+	"auth/user"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// loginRequest is the body expected by Login and CreateSession.
+type loginRequest struct {
+	Login    string `json:"login" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// login binds and validates the request body and authenticates the user,
+// writing any error to c itself. Shared by Login and CreateSession.
+func login(c *gin.Context) (token string, ok bool) {
+	req := loginRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		rest.HandleError(c, err)
+		return "", false
+	}
+
+	token, err := user.Login(req.Login, req.Password)
+
+	if err != nil {
+		rest.HandleError(c, err)
+		return "", false
+	}
+
+	return token, true
+}
+
+/**
+ * @api {post} /auth/login Iniciar Sesion
+ * @apiName login
+ * @apiGroup Seguridad
+ *
+ * @apiDescription Autentica un usuario existente.
+ *
+ * @apiUse TokenResponse
+ *
+ * @apiUse ParamValidationErrors
+ * @apiUse OtherErrors
+ */
+// Login is the controller to authenticate an existing user
+func Login(c *gin.Context) {
+	token, ok := login(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"token": token,
+	})
+}
+
+/**
+ * @api {post} /v1/sessions Create Session
+ * @apiName createSession
+ * @apiGroup Sessions
+ *
+ * @apiDescription Resource-oriented equivalent of /auth/login.
+ *
+ * @apiUse TokenResponse
+ *
+ * @apiUse ParamValidationErrors
+ * @apiUse OtherErrors
+ */
+// CreateSession is the controller for POST /v1/sessions
+func CreateSession(c *gin.Context) {
+	token, ok := login(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(201, gin.H{
+		"token": token,
+	})
+}
+
+/**
+ * @api {post} /auth/logout Cerrar Sesion
+ * @apiName logout
+ * @apiGroup Seguridad
+ *
+ * @apiUse OtherErrors
+ */
+// Logout is the controller to invalidate the caller's session token
+func Logout(c *gin.Context) {
+	if err := user.Logout(bearerToken(c)); err != nil {
+		rest.HandleError(c, err)
+		return
+	}
+
+	c.Status(204)
+}
+
+/**
+ * @api {delete} /v1/sessions/current End Current Session
+ * @apiName endCurrentSession
+ * @apiGroup Sessions
+ *
+ * @apiDescription Resource-oriented equivalent of /auth/logout.
+ *
+ * @apiUse OtherErrors
+ */
+// EndCurrentSession is the controller for DELETE /v1/sessions/current
+func EndCurrentSession(c *gin.Context) {
+	Logout(c)
+}
+
+// bearerToken extracts the session token from an "Authorization: Bearer
+// <token>" header.
+func bearerToken(c *gin.Context) string {
+	const prefix = "Bearer "
+
+	header := c.GetHeader("Authorization")
+	if !strings.HasPrefix(header, prefix) {
+		return ""
+	}
+
+	return strings.TrimPrefix(header, prefix)
+}
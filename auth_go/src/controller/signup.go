@@ -1,12 +1,44 @@
 package controller
 
 import (
+	"auth/tools/idempotency"
 	"auth/tools/rest"
 	"auth/user"
 
 	"github.com/gin-gonic/gin"
 )
 
+// SignUpHandlers is the full handler chain for the legacy signup route. The
+// idempotency middleware guards against a retried request after a network
+// blip producing a spurious duplicate-key error.
+var SignUpHandlers = []gin.HandlerFunc{idempotency.Middleware(), SignUp}
+
+// CreateUserHandlers is the full handler chain for the resource-oriented
+// signup route.
+var CreateUserHandlers = []gin.HandlerFunc{idempotency.Middleware(), CreateUser}
+
+// signUp binds and validates the request body and creates the user,
+// writing any error to c itself. It's shared by SignUp and CreateUser so
+// the two surfaces can disagree on status code and envelope without
+// duplicating validation/error handling.
+func signUp(c *gin.Context) (id string, token string, ok bool) {
+	userRequest := user.NewUserRequest{}
+
+	if err := c.ShouldBindJSON(&userRequest); err != nil {
+		rest.HandleError(c, err)
+		return "", "", false
+	}
+
+	id, token, err := user.SignUp(userRequest)
+
+	if err != nil {
+		rest.HandleError(c, err)
+		return "", "", false
+	}
+
+	return id, token, true
+}
+
 /**
  * @api {post} /auth/signup Registrar Usuario
  * @apiName signup
@@ -28,22 +60,46 @@ import (
  */
 // SignUp is the controller to signup new users
 func SignUp(c *gin.Context) {
-	userRequest := user.NewUserRequest{}
-
-	if err := c.ShouldBindJSON(&userRequest); err != nil {
-		rest.HandleError(c, err)
+	_, token, ok := signUp(c)
+	if !ok {
 		return
 	}
 
-	token, err := user.SignUp(userRequest)
+	c.JSON(200, gin.H{
+		"token": token,
+	})
+}
 
-	if err != nil {
-		rest.HandleError(c, err)
+/**
+ * @api {post} /v1/users Create User
+ * @apiName createUser
+ * @apiGroup Users
+ *
+ * @apiDescription Resource-oriented equivalent of /auth/signup. Responds
+ * 201 Created with a Location header pointing at the new user.
+ *
+ * @apiParamExample {json} Body
+ *    {
+ *      "name": "{Nombre de Usuario}",
+ *      "login": "{Login de usuario}",
+ *      "password": "{Contraseña}"
+ *    }
+ *
+ * @apiUse TokenResponse
+ *
+ * @apiUse ParamValidationErrors
+ * @apiUse OtherErrors
+ */
+// CreateUser is the controller for POST /v1/users
+func CreateUser(c *gin.Context) {
+	id, token, ok := signUp(c)
+	if !ok {
 		return
 	}
 
-	c.JSON(200, gin.H{
+	c.Header("Location", "/v1/users/"+id)
+	c.JSON(201, gin.H{
+		"id":    id,
 		"token": token,
 	})
-
-}
\ No newline at end of file
+}
@@ -0,0 +1,103 @@
+package hash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Argon2Config tunes the Argon2id cost parameters. It travels with every
+// hash it produces, so changing it only affects passwords hashed from
+// that point on; Verify flags older, weaker hashes via needsRehash.
+type Argon2Config struct {
+	Memory      uint32
+	Time        uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Config is the policy Default uses unless an operator
+// supplies a different Argon2Config.
+var DefaultArgon2Config = Argon2Config{
+	Memory:      65536,
+	Time:        3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Argon2idHasher hashes and verifies passwords with Argon2id, encoding the
+// result as a PHC string: $argon2id$v=19$m=65536,t=3,p=2$salt$hash.
+type Argon2idHasher struct {
+	Config Argon2Config
+}
+
+// NewArgon2idHasher returns a Hasher using cfg's cost parameters.
+func NewArgon2idHasher(cfg Argon2Config) *Argon2idHasher {
+	return &Argon2idHasher{Config: cfg}
+}
+
+// Hash implements Hasher.
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.Config.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.Config.Time, h.Config.Memory, h.Config.Parallelism, h.Config.KeyLength)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.Config.Memory, h.Config.Time, h.Config.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify implements Hasher, re-deriving the key under encoded's own
+// parameters and flagging needsRehash when those parameters are weaker
+// than h.Config's current policy.
+func (h *Argon2idHasher) Verify(password, encoded string) (ok bool, needsRehash bool, err error) {
+	cfg, salt, key, err := parseArgon2id(encoded)
+	if err != nil {
+		return false, false, err
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, cfg.Time, cfg.Memory, cfg.Parallelism, uint32(len(key)))
+
+	ok = subtle.ConstantTimeCompare(candidate, key) == 1
+	needsRehash = ok && isWeaker(cfg, h.Config)
+	return ok, needsRehash, nil
+}
+
+func isWeaker(got, want Argon2Config) bool {
+	return got.Memory < want.Memory || got.Time < want.Time || got.Parallelism < want.Parallelism
+}
+
+func parseArgon2id(encoded string) (cfg Argon2Config, salt, key []byte, err error) {
+	// $argon2id$v=19$m=65536,t=3,p=2$salt$hash
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return Argon2Config{}, nil, nil, errors.New("hash: not an argon2id PHC string")
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &cfg.Memory, &cfg.Time, &cfg.Parallelism); err != nil {
+		return Argon2Config{}, nil, nil, fmt.Errorf("hash: malformed argon2id parameters: %w", err)
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return Argon2Config{}, nil, nil, err
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return Argon2Config{}, nil, nil, err
+	}
+
+	return cfg, salt, key, nil
+}
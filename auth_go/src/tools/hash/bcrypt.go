@@ -0,0 +1,41 @@
+package hash
+
+import "golang.org/x/crypto/bcrypt"
+
+// bcryptDefaultCost is only used to verify pre-Argon2id hashes; bcrypt
+// never hashes new passwords on its own, so the cost it was originally
+// generated with doesn't matter here.
+const bcryptDefaultCost = bcrypt.DefaultCost
+
+// BcryptHasher verifies passwords hashed with bcrypt, kept so users
+// created before the Argon2id migration still authenticate correctly.
+// Verify always reports needsRehash so they migrate to Argon2id the next
+// time they log in.
+type BcryptHasher struct {
+	Cost int
+}
+
+// NewBcryptHasher returns a Hasher using the given bcrypt cost.
+func NewBcryptHasher(cost int) *BcryptHasher {
+	return &BcryptHasher{Cost: cost}
+}
+
+// Hash implements Hasher. It exists for backward compatibility only;
+// Default is Argon2id, so new hashes don't normally come from here.
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	encoded, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	return string(encoded), err
+}
+
+// Verify implements Hasher.
+func (h *BcryptHasher) Verify(password, encoded string) (ok bool, needsRehash bool, err error) {
+	err = bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	switch err {
+	case nil:
+		return true, true, nil
+	case bcrypt.ErrMismatchedHashAndPassword:
+		return false, false, nil
+	default:
+		return false, false, err
+	}
+}
@@ -0,0 +1,46 @@
+// Package hash hashes and verifies user passwords behind a small Hasher
+// abstraction, so the algorithm and its cost parameters can change over
+// time without invalidating passwords hashed under the old ones.
+package hash
+
+import (
+	"errors"
+	"strings"
+)
+
+// Hasher hashes and verifies passwords, encoding its own parameters into
+// the returned string (PHC format) so a later policy change doesn't
+// invalidate hashes created under the old one.
+type Hasher interface {
+	Hash(password string) (string, error)
+	Verify(password, encoded string) (ok bool, needsRehash bool, err error)
+}
+
+// Default is the Hasher new passwords are hashed with. It defaults to
+// Argon2id under DefaultArgon2Config; operators tune cost parameters by
+// replacing it with NewArgon2idHasher(cfg) at startup, no code change
+// needed.
+var Default Hasher = NewArgon2idHasher(DefaultArgon2Config)
+
+// Hash hashes password with Default.
+func Hash(password string) (string, error) {
+	return Default.Hash(password)
+}
+
+// Verify dispatches to the Hasher matching encoded's algorithm, detected
+// from its PHC prefix, so a bcrypt hash created before the Argon2id
+// migration still verifies. needsRehash is true whenever encoded isn't
+// already a hash Default would consider up to date.
+func Verify(password, encoded string) (ok bool, needsRehash bool, err error) {
+	switch {
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		return Default.Verify(password, encoded)
+	case strings.HasPrefix(encoded, "$2a$"), strings.HasPrefix(encoded, "$2b$"), strings.HasPrefix(encoded, "$2y$"):
+		ok, _, err = bcryptHasher.Verify(password, encoded)
+		return ok, ok, err
+	default:
+		return false, false, errors.New("hash: unrecognized hash format")
+	}
+}
+
+var bcryptHasher = NewBcryptHasher(bcryptDefaultCost)
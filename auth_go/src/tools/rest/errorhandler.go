@@ -9,8 +9,38 @@ import (
 	validator "gopkg.in/go-playground/validator.v8"
 )
 
-var alreadyExistError = gin.H{"error": "Already exist"}
-var internalServerError = gin.H{"error": "Internal server error"}
+const (
+	codeValidation   = "validation_error"
+	codeDuplicateKey = "duplicate_key"
+	codeUnauthorized = "unauthorized"
+	codeInternal     = "internal_error"
+)
+
+var internalServerError = ErrorResponse{Code: codeInternal, Message: "Internal server error"}
+
+// ErrUnauthorized is the sentinel a controller/domain error should be (or
+// wrap) to get the 401 branch below instead of falling through to the
+// generic 500 every other plain error gets.
+var ErrUnauthorized = sentinelError("unauthorized")
+
+type sentinelError string
+
+func (e sentinelError) Error() string { return string(e) }
+
+// ErrorResponse is the standard error envelope returned by every controller.
+type ErrorResponse struct {
+	Code        string       `json:"code"`
+	Message     string       `json:"message"`
+	FieldErrors []FieldError `json:"fieldErrors,omitempty"`
+}
+
+// FieldError describes a single field that failed validation, along with
+// every rule it broke.
+type FieldError struct {
+	FieldName     string      `json:"fieldName"`
+	Errors        []string    `json:"errors"`
+	RejectedValue interface{} `json:"rejectedValue,omitempty"`
+}
 
 // HandleError handle any error and output JSON
 func HandleError(c *gin.Context, err interface{}) {
@@ -30,14 +60,25 @@ func HandleError(c *gin.Context, err interface{}) {
 		return
 	}
 
+	if err == ErrUnauthorized {
+		c.JSON(401, ErrorResponse{
+			Code:    codeUnauthorized,
+			Message: "Authentication required or invalid credentials",
+		})
+		return
+	}
+
 	simpleError, ok := err.(error)
 	if ok {
 		if IsUniqueKeyError(simpleError) {
-			c.JSON(400, alreadyExistError)
-
+			c.JSON(409, ErrorResponse{
+				Code:    codeDuplicateKey,
+				Message: "A resource with that value already exists",
+			})
 		} else {
-			c.JSON(500, gin.H{
-				"error": simpleError.Error(),
+			c.JSON(500, ErrorResponse{
+				Code:    codeInternal,
+				Message: simpleError.Error(),
 			})
 		}
 		return
@@ -51,22 +92,45 @@ func IsUniqueKeyError(err error) bool {
 	return strings.Contains(err.Error(), "duplicate key error")
 }
 
+// handleValidationError groups the validator's per-rule failures by field
+// and translates each one through the locale message catalog before
+// responding with a 422.
 func handleValidationError(c *gin.Context, validationErrors validator.ValidationErrors) {
-	var result []pathMessage
+	locale := localeFromHeader(c.GetHeader("Accept-Language"))
+
+	var order []string
+	byField := make(map[string]*FieldError)
 
 	for _, err := range validationErrors {
-		result = append(result, pathMessage{
-			Path:    strings.ToLower(err.Field),
-			Message: err.Tag,
-		})
+		field := strings.ToLower(err.Field)
+
+		fe, ok := byField[field]
+		if !ok {
+			fe = &FieldError{FieldName: field}
+			if !isSecretField(field) {
+				fe.RejectedValue = err.Value
+			}
+			byField[field] = fe
+			order = append(order, field)
+		}
+
+		fe.Errors = append(fe.Errors, messageFor(locale, field, err.Tag))
 	}
 
-	c.JSON(400, gin.H{
-		"messages": result,
+	result := make([]FieldError, 0, len(order))
+	for _, field := range order {
+		result = append(result, *byField[field])
+	}
+
+	c.JSON(422, ErrorResponse{
+		Code:        codeValidation,
+		Message:     "One or more fields failed validation",
+		FieldErrors: result,
 	})
 }
 
-type pathMessage struct {
-	Path    string `json:"path"`
-	Message string `json:"message"`
-}
\ No newline at end of file
+// isSecretField reports whether field's value must never be echoed back in
+// an error response, e.g. a password reflected into a logged/proxied 422.
+func isSecretField(field string) bool {
+	return field == "password"
+}
@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	validator "gopkg.in/go-playground/validator.v8"
+)
+
+var loginCharset = regexp.MustCompile(`^[a-zA-Z0-9_.]{3,32}$`)
+
+// reservedLogins can't be registered because they're either ambiguous
+// (could be mistaken for a system account) or reserved for future use.
+var reservedLogins = map[string]struct{}{
+	"admin":         {},
+	"administrator": {},
+	"root":          {},
+	"support":       {},
+	"system":        {},
+	"null":          {},
+}
+
+// loginRule implements the "login" validate tag: a restricted charset plus
+// the reserved-name blacklist above.
+func loginRule(v *validator.Validate, topStruct, currentStruct reflect.Value, field reflect.Value, fieldType reflect.Type, fieldKind reflect.Kind, param string) bool {
+	login, ok := field.Interface().(string)
+	if !ok {
+		return false
+	}
+
+	if !loginCharset.MatchString(login) {
+		return false
+	}
+
+	_, reserved := reservedLogins[strings.ToLower(login)]
+	return !reserved
+}
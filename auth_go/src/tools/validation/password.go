@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+
+	validator "gopkg.in/go-playground/validator.v8"
+)
+
+const passwordMinLength = 10
+
+// commonPasswords is a small blacklist of passwords that are too common to
+// accept regardless of how they score on the character-class checks below.
+var commonPasswords = map[string]struct{}{
+	"password":    {},
+	"password1":   {},
+	"12345678":    {},
+	"123456789":   {},
+	"qwerty123":   {},
+	"letmein123":  {},
+	"iloveyou123": {},
+	"admin1234":   {},
+	"welcome123":  {},
+	"changeme123": {},
+}
+
+// passwordRule implements the "password" validate tag: a minimum length,
+// upper/lower/digit/symbol character classes, and a blacklist check, so a
+// failing password always surfaces as the single "password" tag and lets
+// the message catalog explain the requirements.
+func passwordRule(v *validator.Validate, topStruct, currentStruct reflect.Value, field reflect.Value, fieldType reflect.Type, fieldKind reflect.Kind, param string) bool {
+	password, ok := field.Interface().(string)
+	if !ok {
+		return false
+	}
+
+	if _, blacklisted := commonPasswords[strings.ToLower(password)]; blacklisted {
+		return false
+	}
+
+	if len(password) < passwordMinLength {
+		return false
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r), unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	return hasUpper && hasLower && hasDigit && hasSymbol
+}
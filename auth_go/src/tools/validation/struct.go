@@ -0,0 +1,19 @@
+package validation
+
+import (
+	validator "gopkg.in/go-playground/validator.v8"
+)
+
+// PasswordNotEqualLogin is a struct-level rule rejecting a password that is
+// identical to the login, a check field-level rules can't express since
+// each only ever sees a single field. Structs that want it call
+// RegisterStructValidation(PasswordNotEqualLogin, TheirStruct{}) so the
+// field names below stay their own.
+func PasswordNotEqualLogin(v *validator.Validate, structLevel *validator.StructLevel) {
+	login := structLevel.CurrentStruct.FieldByName("Login").String()
+	password := structLevel.CurrentStruct.FieldByName("Password").String()
+
+	if login != "" && login == password {
+		structLevel.ReportError(structLevel.CurrentStruct.FieldByName("Password"), "Password", "password", "sameaslogin")
+	}
+}
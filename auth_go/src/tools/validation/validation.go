@@ -0,0 +1,32 @@
+// Package validation wraps gin's binding validator so the rest of the
+// module can register custom rules in one place instead of reaching into
+// gin/binding directly.
+package validation
+
+import (
+	"github.com/gin-gonic/gin/binding"
+	validator "gopkg.in/go-playground/validator.v8"
+)
+
+// engine returns the validator.Validate instance gin uses to bind and
+// validate JSON request bodies.
+func engine() *validator.Validate {
+	return binding.Validator.Engine().(*validator.Validate)
+}
+
+// RegisterValidation registers a field-level rule under tag, making it
+// available as `validate:"<tag>"` on any struct bound via ShouldBindJSON.
+func RegisterValidation(tag string, fn validator.Func) error {
+	return engine().RegisterValidation(tag, fn)
+}
+
+// RegisterStructValidation registers a rule that runs once per struct,
+// after its field-level rules, for every value of the given types.
+func RegisterStructValidation(fn validator.StructLevelFunc, types ...interface{}) {
+	engine().RegisterStructValidation(fn, types...)
+}
+
+func init() {
+	RegisterValidation("password", passwordRule)
+	RegisterValidation("login", loginRule)
+}
@@ -0,0 +1,50 @@
+package user
+
+import (
+	"context"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/mongo"
+)
+
+// collection is set by Init and points at the "users" collection, which
+// carries a unique index on "login". sessions points at the "sessions"
+// collection that backs Login/Logout/CurrentUser.
+var (
+	collection *mongo.Collection
+	sessions   *mongo.Collection
+)
+
+// Init wires up the collections this package persists to. It's called once
+// from module startup, alongside the rest of the app's Mongo setup.
+func Init(db *mongo.Database) {
+	collection = db.Collection("users")
+	sessions = db.Collection("sessions")
+}
+
+func insert(name, login, passwordHash string) (bson.ObjectID, error) {
+	result, err := collection.InsertOne(context.Background(), bson.NewDocument(
+		bson.EC.String("name", name),
+		bson.EC.String("login", login),
+		bson.EC.String("password", passwordHash),
+	))
+	if err != nil {
+		return bson.ObjectID{}, err
+	}
+	return result.InsertedID.(bson.ObjectID), nil
+}
+
+// updatePassword persists a re-hashed password for userID. It's best
+// effort: a failure here just means the user gets re-hashed again on
+// their next login, so the caller ignores the error rather than failing
+// Login over it.
+func updatePassword(userID bson.ObjectID, passwordHash string) error {
+	_, err := collection.UpdateOne(
+		context.Background(),
+		bson.NewDocument(bson.EC.ObjectID("_id", userID)),
+		bson.NewDocument(bson.EC.SubDocumentFromElements("$set",
+			bson.EC.String("password", passwordHash),
+		)),
+	)
+	return err
+}
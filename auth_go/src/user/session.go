@@ -0,0 +1,117 @@
+package user
+
+import (
+	"auth/tools/hash"
+	"auth/tools/rest"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+)
+
+// ErrInvalidCredentials is returned by Login when the login doesn't exist
+// or the password doesn't match, and by Logout/CurrentUser when the token
+// is empty or doesn't resolve to a session. It's intentionally the same
+// error in every case so a caller can't use it to probe which logins
+// exist, and it's rest.ErrUnauthorized itself so HandleError responds 401
+// instead of falling through to the generic 500.
+var ErrInvalidCredentials = rest.ErrUnauthorized
+
+// Profile is the public view of a user returned by CurrentUser.
+type Profile struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Login string `json:"login"`
+}
+
+// Login verifies login/password against the stored user and, on success,
+// issues a new session token. A password stored under an outdated hasher
+// or cost policy is transparently re-hashed with the current one.
+func Login(login, password string) (string, error) {
+	var doc struct {
+		ID       bson.ObjectID `bson:"_id"`
+		Password string        `bson:"password"`
+	}
+
+	result := collection.FindOne(context.Background(), bson.NewDocument(bson.EC.String("login", login)))
+	if err := result.Decode(&doc); err != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	ok, needsRehash, err := hash.Verify(password, doc.Password)
+	if err != nil || !ok {
+		return "", ErrInvalidCredentials
+	}
+
+	if needsRehash {
+		if rehashed, err := hash.Hash(password); err == nil {
+			updatePassword(doc.ID, rehashed)
+		}
+	}
+
+	return newSession(doc.ID)
+}
+
+// Logout invalidates token so it can no longer be used to authenticate. An
+// empty or already-invalid token is reported as ErrInvalidCredentials
+// rather than treated as a no-op success.
+func Logout(token string) error {
+	if token == "" {
+		return ErrInvalidCredentials
+	}
+
+	result, err := sessions.DeleteOne(context.Background(), bson.NewDocument(bson.EC.String("token", token)))
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
+
+// CurrentUser resolves token to the profile of the user who owns it.
+func CurrentUser(token string) (*Profile, error) {
+	var session struct {
+		UserID bson.ObjectID `bson:"userId"`
+	}
+
+	result := sessions.FindOne(context.Background(), bson.NewDocument(bson.EC.String("token", token)))
+	if err := result.Decode(&session); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	var doc struct {
+		Name  string `bson:"name"`
+		Login string `bson:"login"`
+	}
+
+	result = collection.FindOne(context.Background(), bson.NewDocument(bson.EC.ObjectID("_id", session.UserID)))
+	if err := result.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	return &Profile{ID: session.UserID.Hex(), Name: doc.Name, Login: doc.Login}, nil
+}
+
+func newSession(userID bson.ObjectID) (string, error) {
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = sessions.InsertOne(context.Background(), bson.NewDocument(
+		bson.EC.String("token", token),
+		bson.EC.ObjectID("userId", userID),
+	))
+	return token, err
+}
+
+func newToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
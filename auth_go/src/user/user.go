@@ -0,0 +1,39 @@
+// Package user implements account registration and lookup.
+package user
+
+import (
+	"auth/tools/hash"
+	"auth/tools/validation"
+)
+
+// NewUserRequest is the body expected by controller.SignUp.
+type NewUserRequest struct {
+	Name     string `json:"name" validate:"required"`
+	Login    string `json:"login" validate:"required,login"`
+	Password string `json:"password" validate:"required,password"`
+}
+
+func init() {
+	validation.RegisterStructValidation(validation.PasswordNotEqualLogin, NewUserRequest{})
+}
+
+// SignUp creates a new user from req and returns its id plus a session
+// token for it.
+func SignUp(req NewUserRequest) (id string, token string, err error) {
+	hashed, err := hash.Hash(req.Password)
+	if err != nil {
+		return "", "", err
+	}
+
+	userID, err := insert(req.Name, req.Login, hashed)
+	if err != nil {
+		return "", "", err
+	}
+
+	token, err = newSession(userID)
+	if err != nil {
+		return "", "", err
+	}
+
+	return userID.Hex(), token, nil
+}